@@ -0,0 +1,129 @@
+package packaging
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+
+	"github.com/otiai10/copy"
+)
+
+func init() {
+	Register(&appimagePackager{})
+}
+
+type appimagePackager struct{}
+
+func (p *appimagePackager) Name() string           { return "linux-appimage" }
+func (p *appimagePackager) RequiredOS() string     { return "linux" }
+func (p *appimagePackager) Dependencies() []string { return linuxPackagingDependencies }
+
+func (p *appimagePackager) Init(projectName string) error {
+	if err := assertCorrectOS(p); err != nil {
+		return err
+	}
+	project := CurrentProject()
+	appImageDirectoryPath, err := createPackagingFormatDirectory(project, p.Name())
+	if err != nil {
+		return err
+	}
+
+	appDirPath, err := filepath.Abs(filepath.Join(appImageDirectoryPath, "AppDir"))
+	if err != nil {
+		return fmt.Errorf("failed to resolve absolute path for AppDir directory: %w", err)
+	}
+	if err := os.MkdirAll(appDirPath, 0775); err != nil {
+		return fmt.Errorf("failed to create AppDir directory %s: %w", appDirPath, err)
+	}
+
+	appRunFilePath, err := filepath.Abs(filepath.Join(appDirPath, "AppRun"))
+	if err != nil {
+		return fmt.Errorf("failed to resolve absolute path for AppRun file: %w", err)
+	}
+	if err := writeLinesToFile(appRunFilePath, []string{
+		"#!/bin/sh",
+		`HERE="$(dirname "$(readlink -f "${0}")")"`,
+		`exec "$HERE/usr/bin/` + projectName + `" "$@"`,
+	}); err != nil {
+		return err
+	}
+	if err := os.Chmod(appRunFilePath, 0777); err != nil {
+		return fmt.Errorf("failed to change file permissions for AppRun file: %w", err)
+	}
+
+	desktopFilePath, err := filepath.Abs(filepath.Join(appDirPath, projectName+".desktop"))
+	if err != nil {
+		return fmt.Errorf("failed to resolve absolute path for desktop file: %w", err)
+	}
+	categories := project.Desktop.Categories
+	if len(categories) == 0 {
+		categories = []string{"Utility"}
+	}
+	if err := writeDesktopEntry(desktopFilePath, DesktopEntry{
+		Type:           "Application",
+		Name:           projectName,
+		GenericName:    project.Desktop.GenericName,
+		Comment:        project.Desktop.Comment,
+		Exec:           projectName,
+		Icon:           projectName,
+		Terminal:       false,
+		Categories:     categories,
+		MimeTypes:      project.Desktop.MimeTypes,
+		Keywords:       project.Desktop.Keywords,
+		StartupWMClass: project.Desktop.StartupWMClass,
+	}); err != nil {
+		return err
+	}
+
+	printInitFinished(p.Name())
+	return nil
+}
+
+func (p *appimagePackager) Build(projectName string) error {
+	if err := assertCorrectOS(p); err != nil {
+		return err
+	}
+	appimagetoolBin, err := exec.LookPath("appimagetool")
+	if err != nil {
+		return fmt.Errorf("failed to lookup `appimagetool` executable, please install appimagetool: https://github.com/AppImage/AppImageKit/releases")
+	}
+	tmpPath, err := getTemporaryBuildDirectory(projectName, p.Name())
+	if err != nil {
+		return err
+	}
+	fmt.Printf("hover: Packaging AppImage in %s\n", tmpPath)
+
+	project := CurrentProject()
+	packagingFormatDirectoryPath, err := packagingFormatPath(project, p.Name())
+	if err != nil {
+		return err
+	}
+	appDirTmpPath := filepath.Join(tmpPath, "AppDir")
+	if err := copy.Copy(filepath.Join(packagingFormatDirectoryPath, "AppDir"), appDirTmpPath); err != nil {
+		return fmt.Errorf("could not copy AppDir folder: %w", err)
+	}
+	if err := copy.Copy(OutputDirectoryPath("linux"), filepath.Join(appDirTmpPath, "usr", "bin")); err != nil {
+		return fmt.Errorf("could not copy build folder: %w", err)
+	}
+	if err := copy.Copy(filepath.Join(project.BuildPath, "assets", "icon.png"), filepath.Join(appDirTmpPath, projectName+".png")); err != nil {
+		return fmt.Errorf("could not copy icon: %w", err)
+	}
+
+	outputFileName := removeDashesAndUnderscores(projectName) + "-" + runtime.GOARCH + ".AppImage"
+	outputFilePath := filepath.Join(OutputDirectoryPath(p.Name()), outputFileName)
+
+	cmdBuildAppImage := exec.Command(appimagetoolBin, appDirTmpPath, outputFilePath)
+	cmdBuildAppImage.Dir = tmpPath
+	cmdBuildAppImage.Stdout = os.Stdout
+	cmdBuildAppImage.Stderr = os.Stderr
+	cmdBuildAppImage.Stdin = os.Stdin
+	if err := cmdBuildAppImage.Run(); err != nil {
+		return fmt.Errorf("failed to package AppImage: %w", err)
+	}
+	if err := os.RemoveAll(tmpPath); err != nil {
+		return fmt.Errorf("could not remove packaging configuration folder: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,73 @@
+package packaging
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// darwinInfoPlistTemplate is the Info.plist shared by every macOS bundle
+// target. See https://developer.apple.com/documentation/bundleresources/information_property_list.
+const darwinInfoPlistTemplate = `<?xml version="1.0" encoding="UTF-8"?>
+<!DOCTYPE plist PUBLIC "-//Apple//DTD PLIST 1.0//EN" "http://www.apple.com/DTDs/PropertyList-1.0.dtd">
+<plist version="1.0">
+<dict>
+	<key>CFBundleExecutable</key>
+	<string>%s</string>
+	<key>CFBundleIdentifier</key>
+	<string>%s</string>
+	<key>CFBundleName</key>
+	<string>%s</string>
+	<key>CFBundlePackageType</key>
+	<string>APPL</string>
+	<key>CFBundleVersion</key>
+	<string>%s</string>
+	<key>CFBundleShortVersionString</key>
+	<string>%s</string>
+	<key>NSHighResolutionCapable</key>
+	<true/>
+	<key>LSMinimumSystemVersion</key>
+	<string>10.11</string>
+</dict>
+</plist>
+`
+
+// darwinBundleIdentifier is the CFBundleIdentifier hover assigns every
+// packaged app, since pubspec.yaml has no field for it.
+func darwinBundleIdentifier(projectName string) string {
+	return "io.hover." + removeDashesAndUnderscores(projectName)
+}
+
+func writeDarwinInfoPlist(filePath string, projectName string, version string) error {
+	content := fmt.Sprintf(darwinInfoPlistTemplate, projectName, darwinBundleIdentifier(projectName), projectName, version, version)
+	if err := os.WriteFile(filePath, []byte(content), 0664); err != nil {
+		return fmt.Errorf("could not write %s: %w", filePath, err)
+	}
+	return nil
+}
+
+// scaffoldDarwinAppBundle lays out an empty <projectName>.app bundle at
+// appBundlePath with its Info.plist, ready for Build to copy the compiled
+// binary into Contents/MacOS.
+func scaffoldDarwinAppBundle(appBundlePath string, projectName string, version string) error {
+	macOSDirectoryPath, err := filepath.Abs(filepath.Join(appBundlePath, "Contents", "MacOS"))
+	if err != nil {
+		return fmt.Errorf("failed to resolve absolute path for Contents/MacOS directory: %w", err)
+	}
+	if err := os.MkdirAll(macOSDirectoryPath, 0775); err != nil {
+		return fmt.Errorf("failed to create %s: %w", macOSDirectoryPath, err)
+	}
+	resourcesDirectoryPath, err := filepath.Abs(filepath.Join(appBundlePath, "Contents", "Resources"))
+	if err != nil {
+		return fmt.Errorf("failed to resolve absolute path for Contents/Resources directory: %w", err)
+	}
+	if err := os.MkdirAll(resourcesDirectoryPath, 0775); err != nil {
+		return fmt.Errorf("failed to create %s: %w", resourcesDirectoryPath, err)
+	}
+
+	infoPlistPath, err := filepath.Abs(filepath.Join(appBundlePath, "Contents", "Info.plist"))
+	if err != nil {
+		return fmt.Errorf("failed to resolve absolute path for Info.plist: %w", err)
+	}
+	return writeDarwinInfoPlist(infoPlistPath, projectName, version)
+}
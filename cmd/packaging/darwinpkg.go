@@ -0,0 +1,93 @@
+package packaging
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	"github.com/otiai10/copy"
+)
+
+func init() {
+	Register(&darwinPkgPackager{})
+}
+
+// darwinPkgPackager builds a macOS .pkg installer via pkgbuild.
+type darwinPkgPackager struct{}
+
+func (p *darwinPkgPackager) Name() string           { return "darwin-pkg" }
+func (p *darwinPkgPackager) RequiredOS() string     { return "darwin" }
+func (p *darwinPkgPackager) Dependencies() []string { return nil }
+
+func (p *darwinPkgPackager) Init(projectName string) error {
+	if err := assertCorrectOS(p); err != nil {
+		return err
+	}
+	project := CurrentProject()
+	pkgDirectoryPath, err := createPackagingFormatDirectory(project, p.Name())
+	if err != nil {
+		return err
+	}
+
+	appBundlePath, err := filepath.Abs(filepath.Join(pkgDirectoryPath, "root", "Applications", projectName+".app"))
+	if err != nil {
+		return fmt.Errorf("failed to resolve absolute path for app bundle: %w", err)
+	}
+	if err := scaffoldDarwinAppBundle(appBundlePath, projectName, project.Version); err != nil {
+		return err
+	}
+
+	printInitFinished(p.Name())
+	return nil
+}
+
+func (p *darwinPkgPackager) Build(projectName string) error {
+	if err := assertCorrectOS(p); err != nil {
+		return err
+	}
+	pkgbuildBin, err := exec.LookPath("pkgbuild")
+	if err != nil {
+		return fmt.Errorf("failed to lookup `pkgbuild` executable, pkgbuild ships with the Xcode command line tools")
+	}
+	tmpPath, err := getTemporaryBuildDirectory(projectName, p.Name())
+	if err != nil {
+		return err
+	}
+	fmt.Printf("hover: Packaging pkg in %s\n", tmpPath)
+
+	project := CurrentProject()
+	packagingFormatDirectoryPath, err := packagingFormatPath(project, p.Name())
+	if err != nil {
+		return err
+	}
+	if err := copy.Copy(packagingFormatDirectoryPath, tmpPath); err != nil {
+		return fmt.Errorf("could not copy packaging configuration folder: %w", err)
+	}
+
+	appBundlePath := filepath.Join(tmpPath, "root", "Applications", projectName+".app")
+	if err := copy.Copy(OutputDirectoryPath("darwin"), filepath.Join(appBundlePath, "Contents", "MacOS")); err != nil {
+		return fmt.Errorf("could not copy build folder: %w", err)
+	}
+
+	outputFileName := removeDashesAndUnderscores(projectName) + ".pkg"
+	outputFilePath := filepath.Join(OutputDirectoryPath(p.Name()), outputFileName)
+
+	cmdBuildPkg := exec.Command(pkgbuildBin,
+		"--root", filepath.Join(tmpPath, "root"),
+		"--identifier", darwinBundleIdentifier(projectName),
+		"--version", project.Version,
+		"--install-location", "/",
+		outputFilePath)
+	cmdBuildPkg.Dir = tmpPath
+	cmdBuildPkg.Stdout = os.Stdout
+	cmdBuildPkg.Stderr = os.Stderr
+	cmdBuildPkg.Stdin = os.Stdin
+	if err := cmdBuildPkg.Run(); err != nil {
+		return fmt.Errorf("failed to package pkg: %w", err)
+	}
+	if err := os.RemoveAll(tmpPath); err != nil {
+		return fmt.Errorf("could not remove packaging configuration folder: %w", err)
+	}
+	return nil
+}
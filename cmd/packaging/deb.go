@@ -0,0 +1,431 @@
+package packaging
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"crypto/md5"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"os/user"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/blakesmith/ar"
+	"github.com/otiai10/copy"
+)
+
+func init() {
+	Register(&debPackager{})
+}
+
+type debPackager struct{}
+
+func (p *debPackager) Name() string           { return "linux-deb" }
+func (p *debPackager) RequiredOS() string     { return "" }
+func (p *debPackager) Dependencies() []string { return linuxPackagingDependencies }
+
+func (p *debPackager) Init(projectName string) error {
+	project := CurrentProject()
+	author := project.Author
+	if author == "" {
+		fmt.Println("hover: Missing author field in pubspec.yaml")
+		u, err := user.Current()
+		if err != nil {
+			return fmt.Errorf("couldn't get current user: %w", err)
+		}
+		author = u.Username
+		fmt.Printf("hover: Using this username from system instead: %s\n", author)
+	}
+
+	debDirectoryPath, err := createPackagingFormatDirectory(project, p.Name())
+	if err != nil {
+		return err
+	}
+	debDebianDirectoryPath, err := filepath.Abs(filepath.Join(debDirectoryPath, "DEBIAN"))
+	if err != nil {
+		return fmt.Errorf("failed to resolve absolute path for DEBIAN directory: %w", err)
+	}
+	if err := os.MkdirAll(debDebianDirectoryPath, 0775); err != nil {
+		return fmt.Errorf("failed to create DEBIAN directory %s: %w", debDebianDirectoryPath, err)
+	}
+
+	binDirectoryPath, err := filepath.Abs(filepath.Join(debDirectoryPath, "usr", "bin"))
+	if err != nil {
+		return fmt.Errorf("failed to resolve absolute path for bin directory: %w", err)
+	}
+	if err := os.MkdirAll(binDirectoryPath, 0775); err != nil {
+		return fmt.Errorf("failed to create bin directory %s: %w", binDirectoryPath, err)
+	}
+
+	applicationsDirectoryPath, err := filepath.Abs(filepath.Join(debDirectoryPath, "usr", "share", "applications"))
+	if err != nil {
+		return fmt.Errorf("failed to resolve absolute path for applications directory: %w", err)
+	}
+	if err := os.MkdirAll(applicationsDirectoryPath, 0775); err != nil {
+		return fmt.Errorf("failed to create applications directory %s: %w", applicationsDirectoryPath, err)
+	}
+
+	controlFilePath, err := filepath.Abs(filepath.Join(debDebianDirectoryPath, "control"))
+	if err != nil {
+		return fmt.Errorf("failed to resolve absolute path for control file: %w", err)
+	}
+	if err := writeLinesToFile(controlFilePath, []string{
+		"Package: " + removeDashesAndUnderscores(projectName),
+		"Architecture: " + runtime.GOARCH,
+		"Maintainer: @" + author,
+		"Priority: optional",
+		"Version: " + project.Version,
+		"Description: " + project.Description,
+		"Depends: " + strings.Join(p.Dependencies(), ","),
+	}); err != nil {
+		return err
+	}
+
+	binFilePath, err := filepath.Abs(filepath.Join(binDirectoryPath, removeDashesAndUnderscores(projectName)))
+	if err != nil {
+		return fmt.Errorf("failed to resolve absolute path for bin file: %w", err)
+	}
+	if err := writeLinesToFile(binFilePath, []string{
+		"#!/bin/sh",
+		"/usr/lib/" + projectName + "/" + projectName,
+	}); err != nil {
+		return err
+	}
+	if err := os.Chmod(binFilePath, 0755); err != nil {
+		return fmt.Errorf("failed to change file permissions for bin file: %w", err)
+	}
+
+	desktopFilePath, err := filepath.Abs(filepath.Join(applicationsDirectoryPath, projectName+".desktop"))
+	if err != nil {
+		return fmt.Errorf("failed to resolve absolute path for desktop file: %w", err)
+	}
+	if err := writeDesktopEntry(desktopFilePath, DesktopEntry{
+		Type:           "Application",
+		Name:           projectName,
+		GenericName:    project.Desktop.GenericName,
+		Comment:        project.Desktop.Comment,
+		Exec:           "/usr/bin/" + projectName,
+		Icon:           "/usr/lib/" + projectName + "/assets/icon.png",
+		Terminal:       false,
+		Categories:     project.Desktop.Categories,
+		MimeTypes:      project.Desktop.MimeTypes,
+		Keywords:       project.Desktop.Keywords,
+		StartupWMClass: project.Desktop.StartupWMClass,
+	}); err != nil {
+		return err
+	}
+
+	printInitFinished(p.Name())
+	return nil
+}
+
+func (p *debPackager) Build(projectName string) error {
+	tmpPath, err := getTemporaryBuildDirectory(projectName, p.Name())
+	if err != nil {
+		return err
+	}
+	fmt.Printf("hover: Packaging deb in %s\n", tmpPath)
+
+	libDirectoryPath, err := filepath.Abs(filepath.Join(tmpPath, "usr", "lib"))
+	if err != nil {
+		return fmt.Errorf("failed to resolve absolute path for bin directory: %w", err)
+	}
+	if err := copy.Copy(OutputDirectoryPath("linux"), filepath.Join(libDirectoryPath, projectName)); err != nil {
+		return fmt.Errorf("could not copy build folder: %w", err)
+	}
+
+	project := CurrentProject()
+	packagingFormatDirectoryPath, err := packagingFormatPath(project, p.Name())
+	if err != nil {
+		return err
+	}
+	if err := copy.Copy(packagingFormatDirectoryPath, tmpPath); err != nil {
+		return fmt.Errorf("could not copy packaging configuration folder: %w", err)
+	}
+
+	outputFileName := removeDashesAndUnderscores(projectName) + "_" + runtime.GOARCH + ".deb"
+	outputFilePath := filepath.Join(OutputDirectoryPath(p.Name()), outputFileName)
+
+	if err := buildDebArchive(tmpPath, outputFilePath); err != nil {
+		return fmt.Errorf("failed to package deb: %w", err)
+	}
+	if err := os.RemoveAll(tmpPath); err != nil {
+		return fmt.Errorf("could not remove packaging configuration folder: %w", err)
+	}
+	return nil
+}
+
+// debBinaryVersion is the ar archive's first member, fixed by the .deb
+// format at version 2.0. See https://manpages.debian.org/deb.5.
+const debBinaryVersion = "2.0\n"
+
+// debMaintainerScripts are the control-tarball members dpkg runs at install
+// and removal time, read from go/packaging/linux-deb/DEBIAN if present.
+var debMaintainerScripts = map[string]bool{
+	"preinst":  true,
+	"postinst": true,
+	"prerm":    true,
+	"postrm":   true,
+}
+
+// buildDebArchive assembles rootPath (the scaffolded package tree, including
+// its DEBIAN control directory) into a .deb at outputFilePath, writing the ar
+// archive directly instead of shelling out to dpkg-deb.
+func buildDebArchive(rootPath string, outputFilePath string) error {
+	debianDirectoryPath := filepath.Join(rootPath, "DEBIAN")
+
+	installedSizeKB, err := directorySizeKB(rootPath, debianDirectoryPath)
+	if err != nil {
+		return fmt.Errorf("could not compute installed size: %w", err)
+	}
+	if err := appendInstalledSizeToControl(filepath.Join(debianDirectoryPath, "control"), installedSizeKB); err != nil {
+		return err
+	}
+
+	// data.tar.gz must be written outside rootPath: writing it inside the
+	// tree it's tarring means the in-progress file gets discovered mid-walk
+	// and included in its own archive as a spurious empty entry.
+	dataTarGzPath := filepath.Join(filepath.Dir(rootPath), filepath.Base(rootPath)+"-data.tar.gz")
+	md5sums, err := writeDataTarGz(dataTarGzPath, rootPath, debianDirectoryPath)
+	if err != nil {
+		return fmt.Errorf("could not write data.tar.gz: %w", err)
+	}
+	defer os.Remove(dataTarGzPath)
+
+	controlTarGzPath := filepath.Join(rootPath, "control.tar.gz")
+	if err := writeControlTarGz(controlTarGzPath, debianDirectoryPath, md5sums); err != nil {
+		return fmt.Errorf("could not write control.tar.gz: %w", err)
+	}
+
+	outputFile, err := os.Create(outputFilePath)
+	if err != nil {
+		return fmt.Errorf("could not create %s: %w", outputFilePath, err)
+	}
+	defer outputFile.Close()
+
+	arWriter := ar.NewWriter(outputFile)
+	if err := arWriter.WriteGlobalHeader(); err != nil {
+		return fmt.Errorf("could not write ar global header: %w", err)
+	}
+	if err := writeArMember(arWriter, "debian-binary", []byte(debBinaryVersion)); err != nil {
+		return err
+	}
+	if err := writeArFileMember(arWriter, "control.tar.gz", controlTarGzPath); err != nil {
+		return err
+	}
+	if err := writeArFileMember(arWriter, "data.tar.gz", dataTarGzPath); err != nil {
+		return err
+	}
+	return nil
+}
+
+func writeArMember(w *ar.Writer, name string, content []byte) error {
+	if err := w.WriteHeader(&ar.Header{
+		Name:    name,
+		Size:    int64(len(content)),
+		Mode:    0644,
+		ModTime: time.Unix(0, 0),
+	}); err != nil {
+		return fmt.Errorf("could not write ar header for %s: %w", name, err)
+	}
+	if _, err := w.Write(content); err != nil {
+		return fmt.Errorf("could not write ar member %s: %w", name, err)
+	}
+	return nil
+}
+
+func writeArFileMember(w *ar.Writer, name string, filePath string) error {
+	content, err := os.ReadFile(filePath)
+	if err != nil {
+		return fmt.Errorf("could not read %s: %w", filePath, err)
+	}
+	return writeArMember(w, name, content)
+}
+
+// directorySizeKB returns the installed size of rootPath in KiB, excluding
+// excludePath, rounded up per the `Installed-Size` field's convention.
+func directorySizeKB(rootPath string, excludePath string) (int64, error) {
+	var totalBytes int64
+	err := filepath.Walk(rootPath, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			if path == excludePath {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		totalBytes += info.Size()
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return (totalBytes + 1023) / 1024, nil
+}
+
+func appendInstalledSizeToControl(controlFilePath string, installedSizeKB int64) error {
+	file, err := os.OpenFile(controlFilePath, os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("could not open control file: %w", err)
+	}
+	defer file.Close()
+	if _, err := file.WriteString(fmt.Sprintf("Installed-Size: %d\n", installedSizeKB)); err != nil {
+		return fmt.Errorf("could not append Installed-Size to control file: %w", err)
+	}
+	return nil
+}
+
+// writeDataTarGz tars and gzips everything under rootPath except
+// excludePath into outputPath, honoring each file's existing executable bit,
+// and returns the control tarball's `md5sums` file content.
+func writeDataTarGz(outputPath string, rootPath string, excludePath string) (string, error) {
+	file, err := os.Create(outputPath)
+	if err != nil {
+		return "", fmt.Errorf("could not create %s: %w", outputPath, err)
+	}
+	defer file.Close()
+
+	gzipWriter := gzip.NewWriter(file)
+	tarWriter := tar.NewWriter(gzipWriter)
+
+	var md5sumsLines []string
+	err = filepath.Walk(rootPath, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if path == rootPath || path == excludePath {
+			if path == excludePath {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		relativePath, err := filepath.Rel(rootPath, path)
+		if err != nil {
+			return err
+		}
+		tarPath := "./" + filepath.ToSlash(relativePath)
+
+		if info.IsDir() {
+			return tarWriter.WriteHeader(&tar.Header{
+				Name:     tarPath + "/",
+				Typeflag: tar.TypeDir,
+				Mode:     0755,
+				ModTime:  time.Unix(0, 0),
+			})
+		}
+
+		if info.Mode()&os.ModeSymlink != 0 {
+			linkTarget, err := os.Readlink(path)
+			if err != nil {
+				return fmt.Errorf("could not read symlink %s: %w", path, err)
+			}
+			return tarWriter.WriteHeader(&tar.Header{
+				Name:     tarPath,
+				Typeflag: tar.TypeSymlink,
+				Linkname: linkTarget,
+				Mode:     0777,
+				ModTime:  time.Unix(0, 0),
+			})
+		}
+
+		mode := int64(0644)
+		if info.Mode()&0111 != 0 {
+			mode = 0755
+		}
+		if err := tarWriter.WriteHeader(&tar.Header{
+			Name:     tarPath,
+			Typeflag: tar.TypeReg,
+			Size:     info.Size(),
+			Mode:     mode,
+			ModTime:  time.Unix(0, 0),
+		}); err != nil {
+			return err
+		}
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		if _, err := tarWriter.Write(content); err != nil {
+			return err
+		}
+		sum := md5.Sum(content)
+		md5sumsLines = append(md5sumsLines, hex.EncodeToString(sum[:])+"  "+relativePath)
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+	if err := tarWriter.Close(); err != nil {
+		return "", err
+	}
+	if err := gzipWriter.Close(); err != nil {
+		return "", err
+	}
+
+	sort.Strings(md5sumsLines)
+	return strings.Join(md5sumsLines, "\n") + "\n", nil
+}
+
+// writeControlTarGz tars and gzips the DEBIAN control directory's `control`
+// file, any preinst/postinst/prerm/postrm maintainer scripts it contains, and
+// the generated md5sums file into outputPath.
+func writeControlTarGz(outputPath string, debianDirectoryPath string, md5sums string) error {
+	file, err := os.Create(outputPath)
+	if err != nil {
+		return fmt.Errorf("could not create %s: %w", outputPath, err)
+	}
+	defer file.Close()
+
+	gzipWriter := gzip.NewWriter(file)
+	tarWriter := tar.NewWriter(gzipWriter)
+
+	if err := writeTarFile(tarWriter, "./md5sums", []byte(md5sums), 0644); err != nil {
+		return err
+	}
+
+	entries, err := os.ReadDir(debianDirectoryPath)
+	if err != nil {
+		return fmt.Errorf("could not read %s: %w", debianDirectoryPath, err)
+	}
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		content, err := os.ReadFile(filepath.Join(debianDirectoryPath, entry.Name()))
+		if err != nil {
+			return fmt.Errorf("could not read %s: %w", entry.Name(), err)
+		}
+		mode := int64(0644)
+		if debMaintainerScripts[entry.Name()] {
+			mode = 0755
+		}
+		if err := writeTarFile(tarWriter, "./"+entry.Name(), content, mode); err != nil {
+			return err
+		}
+	}
+
+	if err := tarWriter.Close(); err != nil {
+		return err
+	}
+	return gzipWriter.Close()
+}
+
+func writeTarFile(tarWriter *tar.Writer, name string, content []byte, mode int64) error {
+	if err := tarWriter.WriteHeader(&tar.Header{
+		Name:     name,
+		Typeflag: tar.TypeReg,
+		Size:     int64(len(content)),
+		Mode:     mode,
+		ModTime:  time.Unix(0, 0),
+	}); err != nil {
+		return fmt.Errorf("could not write tar header for %s: %w", name, err)
+	}
+	_, err := tarWriter.Write(content)
+	return err
+}
@@ -0,0 +1,132 @@
+package packaging
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// DesktopEntryOptions are the optional, user-configurable desktop entry
+// fields sourced from the `desktopEntry` section of hover.yaml.
+type DesktopEntryOptions struct {
+	Categories     []string
+	MimeTypes      []string
+	Keywords       []string
+	StartupWMClass string
+	GenericName    string
+	Comment        string
+}
+
+// desktopEntrySpecVersion is the version of the desktop-entry-spec the
+// generated file conforms to, not the packaged application's version.
+const desktopEntrySpecVersion = "1.5"
+
+// DesktopEntry is a freedesktop.org .desktop file's [Desktop Entry] group.
+// See https://specifications.freedesktop.org/desktop-entry-spec/latest/.
+type DesktopEntry struct {
+	Type           string
+	Name           string
+	GenericName    string
+	Comment        string
+	Exec           string
+	Icon           string
+	Terminal       bool
+	Categories     []string
+	MimeTypes      []string
+	Keywords       []string
+	StartupWMClass string
+}
+
+// allowedExecFieldCodes are the field codes desktop-entry-spec permits in an
+// Exec= value.
+var allowedExecFieldCodes = map[byte]bool{
+	'f': true, 'F': true, 'u': true, 'U': true, 'i': true, 'c': true, 'k': true,
+}
+
+func validateExec(exec string) error {
+	for i := 0; i < len(exec); i++ {
+		if exec[i] != '%' {
+			continue
+		}
+		if i+1 >= len(exec) {
+			return fmt.Errorf("exec %q ends with a dangling %%", exec)
+		}
+		next := exec[i+1]
+		i++
+		if next == '%' {
+			continue
+		}
+		if !allowedExecFieldCodes[next] {
+			return fmt.Errorf("exec %q uses unsupported field code %%%c", exec, next)
+		}
+	}
+	return nil
+}
+
+// escapeValue escapes a value per the desktop-entry-spec rules for string and
+// localestring values: only backslashes, newlines, tabs, and carriage returns
+// need escaping. There is no quoting mechanism for these value types, so
+// values are always written verbatim (unquoted), spaces included.
+func escapeValue(value string) string {
+	replacer := strings.NewReplacer(`\`, `\\`, "\n", `\n`, "\t", `\t`, "\r", `\r`)
+	return replacer.Replace(value)
+}
+
+func escapeList(values []string) string {
+	if len(values) == 0 {
+		return ""
+	}
+	escaped := make([]string, len(values))
+	for i, value := range values {
+		escaped[i] = escapeValue(value)
+	}
+	return strings.Join(escaped, ";") + ";"
+}
+
+// Marshal renders the entry as a spec-compliant .desktop file. It drops the
+// deprecated `Encoding` key and escapes string and list values per the
+// desktop-entry-spec. Exec is checked by validateExec and written as given,
+// without escaping.
+func (e DesktopEntry) Marshal() ([]byte, error) {
+	if err := validateExec(e.Exec); err != nil {
+		return nil, err
+	}
+
+	lines := []string{"[Desktop Entry]"}
+	lines = append(lines, "Version="+desktopEntrySpecVersion)
+	lines = append(lines, "Type="+e.Type)
+	lines = append(lines, "Name="+escapeValue(e.Name))
+	if e.GenericName != "" {
+		lines = append(lines, "GenericName="+escapeValue(e.GenericName))
+	}
+	if e.Comment != "" {
+		lines = append(lines, "Comment="+escapeValue(e.Comment))
+	}
+	lines = append(lines, "Exec="+e.Exec)
+	lines = append(lines, "Icon="+escapeValue(e.Icon))
+	lines = append(lines, fmt.Sprintf("Terminal=%t", e.Terminal))
+	if categories := escapeList(e.Categories); categories != "" {
+		lines = append(lines, "Categories="+categories)
+	}
+	if mimeTypes := escapeList(e.MimeTypes); mimeTypes != "" {
+		lines = append(lines, "MimeType="+mimeTypes)
+	}
+	if keywords := escapeList(e.Keywords); keywords != "" {
+		lines = append(lines, "Keywords="+keywords)
+	}
+	if e.StartupWMClass != "" {
+		lines = append(lines, "StartupWMClass="+escapeValue(e.StartupWMClass))
+	}
+	return []byte(strings.Join(lines, "\n") + "\n"), nil
+}
+
+func writeDesktopEntry(filePath string, entry DesktopEntry) error {
+	content, err := entry.Marshal()
+	if err != nil {
+		return fmt.Errorf("invalid desktop entry for %s: %w", filePath, err)
+	}
+	if err := os.WriteFile(filePath, content, 0664); err != nil {
+		return fmt.Errorf("could not write %s: %w", filePath, err)
+	}
+	return nil
+}
@@ -0,0 +1,88 @@
+package packaging
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	"github.com/otiai10/copy"
+)
+
+func init() {
+	Register(&dmgPackager{})
+}
+
+// dmgPackager builds a macOS .dmg disk image containing the app bundle.
+type dmgPackager struct{}
+
+func (p *dmgPackager) Name() string           { return "darwin-dmg" }
+func (p *dmgPackager) RequiredOS() string     { return "darwin" }
+func (p *dmgPackager) Dependencies() []string { return nil }
+
+func (p *dmgPackager) Init(projectName string) error {
+	if err := assertCorrectOS(p); err != nil {
+		return err
+	}
+	project := CurrentProject()
+	dmgDirectoryPath, err := createPackagingFormatDirectory(project, p.Name())
+	if err != nil {
+		return err
+	}
+
+	appBundlePath, err := filepath.Abs(filepath.Join(dmgDirectoryPath, projectName+".app"))
+	if err != nil {
+		return fmt.Errorf("failed to resolve absolute path for app bundle: %w", err)
+	}
+	if err := scaffoldDarwinAppBundle(appBundlePath, projectName, project.Version); err != nil {
+		return err
+	}
+
+	printInitFinished(p.Name())
+	return nil
+}
+
+func (p *dmgPackager) Build(projectName string) error {
+	if err := assertCorrectOS(p); err != nil {
+		return err
+	}
+	hdiutilBin, err := exec.LookPath("hdiutil")
+	if err != nil {
+		return fmt.Errorf("failed to lookup `hdiutil` executable, hdiutil ships with macOS")
+	}
+	tmpPath, err := getTemporaryBuildDirectory(projectName, p.Name())
+	if err != nil {
+		return err
+	}
+	fmt.Printf("hover: Packaging dmg in %s\n", tmpPath)
+
+	project := CurrentProject()
+	packagingFormatDirectoryPath, err := packagingFormatPath(project, p.Name())
+	if err != nil {
+		return err
+	}
+	if err := copy.Copy(packagingFormatDirectoryPath, tmpPath); err != nil {
+		return fmt.Errorf("could not copy packaging configuration folder: %w", err)
+	}
+
+	appBundlePath := filepath.Join(tmpPath, projectName+".app")
+	if err := copy.Copy(OutputDirectoryPath("darwin"), filepath.Join(appBundlePath, "Contents", "MacOS")); err != nil {
+		return fmt.Errorf("could not copy build folder: %w", err)
+	}
+
+	outputFileName := removeDashesAndUnderscores(projectName) + ".dmg"
+	outputFilePath := filepath.Join(OutputDirectoryPath(p.Name()), outputFileName)
+
+	cmdBuildDmg := exec.Command(hdiutilBin, "create", "-volname", projectName, "-srcfolder", appBundlePath, "-ov", "-format", "UDZO", outputFilePath)
+	cmdBuildDmg.Dir = tmpPath
+	cmdBuildDmg.Stdout = os.Stdout
+	cmdBuildDmg.Stderr = os.Stderr
+	cmdBuildDmg.Stdin = os.Stdin
+	if err := cmdBuildDmg.Run(); err != nil {
+		return fmt.Errorf("failed to package dmg: %w", err)
+	}
+	if err := os.RemoveAll(tmpPath); err != nil {
+		return fmt.Errorf("could not remove packaging configuration folder: %w", err)
+	}
+	return nil
+}
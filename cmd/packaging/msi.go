@@ -0,0 +1,196 @@
+package packaging
+
+import (
+	"crypto/md5"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+)
+
+func init() {
+	Register(&msiPackager{})
+}
+
+// msiPackager builds a Windows .msi installer via the WiX Toolset, generating
+// a <Component> per file under outputDirectoryPath("windows") since WiX has
+// no equivalent of NSIS's `File /r`.
+type msiPackager struct{}
+
+func (p *msiPackager) Name() string           { return "windows-msi" }
+func (p *msiPackager) RequiredOS() string     { return "" }
+func (p *msiPackager) Dependencies() []string { return nil }
+
+// Init only creates an empty go/packaging/windows-msi directory. No
+// configuration files are written there: Build generates the .wxs afresh
+// from the current build output and does not read this directory.
+func (p *msiPackager) Init(projectName string) error {
+	project := CurrentProject()
+	if _, err := createPackagingFormatDirectory(project, p.Name()); err != nil {
+		return err
+	}
+
+	printInitFinished(p.Name())
+	return nil
+}
+
+// wixComponent is a single <Component>/<File> pair generated for a file
+// under outputDirectoryPath("windows").
+type wixComponent struct {
+	ID string
+}
+
+var wixIDReplacer = strings.NewReplacer(".", "_", "-", "_", " ", "_")
+
+func sanitizeWixID(name string) string {
+	return wixIDReplacer.Replace(name)
+}
+
+// deterministicGUID derives a stable, WiX-acceptable GUID from seed so
+// regenerating the .wxs for the same project keeps the same UpgradeCode.
+func deterministicGUID(seed string) string {
+	sum := md5.Sum([]byte(seed))
+	hexSum := hex.EncodeToString(sum[:])
+	return strings.ToUpper(fmt.Sprintf("%s-%s-%s-%s-%s", hexSum[0:8], hexSum[8:12], hexSum[12:16], hexSum[16:20], hexSum[20:32]))
+}
+
+// buildWxsDirectoryXML recursively walks dirPath and returns the nested
+// <Directory>/<Component>/<File> XML for it, appending every component it
+// creates to components.
+func buildWxsDirectoryXML(dirPath string, idPrefix string, components *[]wixComponent) (string, error) {
+	entries, err := os.ReadDir(dirPath)
+	if err != nil {
+		return "", fmt.Errorf("could not read %s: %w", dirPath, err)
+	}
+
+	var sb strings.Builder
+	for _, entry := range entries {
+		fullPath := filepath.Join(dirPath, entry.Name())
+		id := idPrefix + "_" + sanitizeWixID(entry.Name())
+		if entry.IsDir() {
+			childXML, err := buildWxsDirectoryXML(fullPath, id, components)
+			if err != nil {
+				return "", err
+			}
+			sb.WriteString(fmt.Sprintf(`<Directory Id="Dir_%s" Name="%s">%s</Directory>`, id, entry.Name(), childXML))
+			continue
+		}
+
+		componentID := "Component_" + id
+		*components = append(*components, wixComponent{ID: componentID})
+		sb.WriteString(fmt.Sprintf(`<Component Id="%s" Guid="*"><File Id="File_%s" Source="%s" KeyPath="yes"/></Component>`, componentID, id, fullPath))
+	}
+	return sb.String(), nil
+}
+
+const wxsTemplate = `<?xml version="1.0" encoding="UTF-8"?>
+<Wix xmlns="http://schemas.microsoft.com/wix/2006/wi">
+  <Product Id="*" Name="%[1]s" Language="1033" Version="%[2]s" Manufacturer="%[3]s" UpgradeCode="%[4]s">
+    <Package InstallerVersion="200" Compressed="yes" InstallScope="perMachine"/>
+    <MediaTemplate EmbedCab="yes"/>
+    <MajorUpgrade DowngradeErrorMessage="A newer version of %[1]s is already installed."/>
+
+    <Directory Id="TARGETDIR" Name="SourceDir">
+      <Directory Id="ProgramFilesFolder">
+        <Directory Id="INSTALLFOLDER" Name="%[1]s">
+%[5]s
+        </Directory>
+      </Directory>
+      <Directory Id="ProgramMenuFolder">
+        <Directory Id="ApplicationProgramsFolder" Name="%[1]s">
+          <Component Id="ApplicationShortcut" Guid="*">
+            <Shortcut Id="StartMenuShortcut" Name="%[1]s" Target="[INSTALLFOLDER]%[1]s.exe" WorkingDirectory="INSTALLFOLDER"/>
+            <RemoveFolder Id="RemoveApplicationProgramsFolder" On="uninstall"/>
+            <RegistryValue Root="HKCU" Key="Software\%[1]s" Name="installed" Type="integer" Value="1" KeyPath="yes"/>
+          </Component>
+        </Directory>
+      </Directory>
+      <Directory Id="DesktopFolder" Name="Desktop">
+        <Component Id="DesktopShortcut" Guid="*">
+          <Shortcut Id="DesktopShortcutFile" Name="%[1]s" Target="[INSTALLFOLDER]%[1]s.exe" WorkingDirectory="INSTALLFOLDER"/>
+          <RemoveFolder Id="RemoveDesktopFolder" On="uninstall"/>
+          <RegistryValue Root="HKCU" Key="Software\%[1]s" Name="desktopShortcut" Type="integer" Value="1" KeyPath="yes"/>
+        </Component>
+      </Directory>
+    </Directory>
+
+    <Feature Id="MainFeature" Title="%[1]s" Level="1">
+%[6]s      <ComponentRef Id="ApplicationShortcut"/>
+      <ComponentRef Id="DesktopShortcut"/>
+    </Feature>
+  </Product>
+</Wix>
+`
+
+func (p *msiPackager) Build(projectName string) error {
+	candleBin, err := exec.LookPath("candle")
+	if err != nil {
+		return fmt.Errorf("failed to lookup `candle` executable, please install the WiX Toolset")
+	}
+	lightBin, err := exec.LookPath("light")
+	if err != nil {
+		return fmt.Errorf("failed to lookup `light` executable, please install the WiX Toolset")
+	}
+
+	tmpPath, err := getTemporaryBuildDirectory(projectName, p.Name())
+	if err != nil {
+		return err
+	}
+	fmt.Printf("hover: Packaging msi in %s\n", tmpPath)
+
+	buildOutputPath := OutputDirectoryPath("windows")
+	var components []wixComponent
+	filesXML, err := buildWxsDirectoryXML(buildOutputPath, "INSTALLFOLDER", &components)
+	if err != nil {
+		return fmt.Errorf("could not enumerate %s: %w", buildOutputPath, err)
+	}
+
+	var componentRefsXML strings.Builder
+	for _, component := range components {
+		componentRefsXML.WriteString(fmt.Sprintf(`      <ComponentRef Id="%s"/>`+"\n", component.ID))
+	}
+
+	project := CurrentProject()
+	packageName := removeDashesAndUnderscores(projectName)
+	manufacturer := project.Author
+	if manufacturer == "" {
+		manufacturer = packageName
+	}
+
+	wxsContent := fmt.Sprintf(wxsTemplate,
+		projectName, project.Version, manufacturer, deterministicGUID("upgrade-"+packageName),
+		filesXML, componentRefsXML.String())
+
+	wxsFilePath := filepath.Join(tmpPath, packageName+".wxs")
+	if err := os.WriteFile(wxsFilePath, []byte(wxsContent), 0664); err != nil {
+		return fmt.Errorf("could not write %s: %w", wxsFilePath, err)
+	}
+
+	wixobjFilePath := filepath.Join(tmpPath, packageName+".wixobj")
+	cmdCandle := exec.Command(candleBin, "-out", wixobjFilePath, wxsFilePath)
+	cmdCandle.Dir = tmpPath
+	cmdCandle.Stdout = os.Stdout
+	cmdCandle.Stderr = os.Stderr
+	if err := cmdCandle.Run(); err != nil {
+		return fmt.Errorf("failed to compile %s: %w", wxsFilePath, err)
+	}
+
+	outputFileName := packageName + "_" + runtime.GOARCH + ".msi"
+	outputFilePath := filepath.Join(OutputDirectoryPath(p.Name()), outputFileName)
+
+	cmdLight := exec.Command(lightBin, "-out", outputFilePath, wixobjFilePath)
+	cmdLight.Dir = tmpPath
+	cmdLight.Stdout = os.Stdout
+	cmdLight.Stderr = os.Stderr
+	if err := cmdLight.Run(); err != nil {
+		return fmt.Errorf("failed to link %s: %w", wixobjFilePath, err)
+	}
+
+	if err := os.RemoveAll(tmpPath); err != nil {
+		return fmt.Errorf("could not remove packaging configuration folder: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,132 @@
+package packaging
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	"github.com/otiai10/copy"
+)
+
+func init() {
+	Register(&nsisPackager{})
+}
+
+// nsisPackager builds a Windows installer .exe via NSIS.
+type nsisPackager struct{}
+
+func (p *nsisPackager) Name() string           { return "windows-nsis" }
+func (p *nsisPackager) RequiredOS() string     { return "" }
+func (p *nsisPackager) Dependencies() []string { return nil }
+
+func (p *nsisPackager) Init(projectName string) error {
+	project := CurrentProject()
+	nsisDirectoryPath, err := createPackagingFormatDirectory(project, p.Name())
+	if err != nil {
+		return err
+	}
+
+	packageName := removeDashesAndUnderscores(projectName)
+	nsiFilePath, err := filepath.Abs(filepath.Join(nsisDirectoryPath, packageName+".nsi"))
+	if err != nil {
+		return fmt.Errorf("failed to resolve absolute path for nsi file: %w", err)
+	}
+	if err := writeLinesToFile(nsiFilePath, nsisScriptContent(projectName, packageName, project.Version)); err != nil {
+		return err
+	}
+
+	printInitFinished(p.Name())
+	return nil
+}
+
+// nsisScriptContent is a MUI2-driven installer/uninstaller script: it copies
+// the build output under $INSTDIR, adds Start Menu and Desktop shortcuts,
+// writes the uninstaller, and registers it under the standard
+// Add/Remove Programs registry key.
+func nsisScriptContent(projectName string, packageName string, version string) []string {
+	return []string{
+		`!include "MUI2.nsh"`,
+		"",
+		`Name "` + projectName + `"`,
+		`OutFile "` + packageName + `_installer.exe"`,
+		`InstallDir "$PROGRAMFILES64\` + projectName + `"`,
+		`RequestExecutionLevel admin`,
+		"",
+		`!insertmacro MUI_PAGE_WELCOME`,
+		`!insertmacro MUI_PAGE_DIRECTORY`,
+		`!insertmacro MUI_PAGE_INSTFILES`,
+		`!insertmacro MUI_PAGE_FINISH`,
+		`!insertmacro MUI_UNPAGE_CONFIRM`,
+		`!insertmacro MUI_UNPAGE_INSTFILES`,
+		`!insertmacro MUI_LANGUAGE "English"`,
+		"",
+		`Section "Install"`,
+		`  SetOutPath "$INSTDIR"`,
+		`  File /r "build\*.*"`,
+		`  WriteUninstaller "$INSTDIR\uninstall.exe"`,
+		`  CreateShortcut "$SMPROGRAMS\` + projectName + `.lnk" "$INSTDIR\` + projectName + `.exe"`,
+		`  CreateShortcut "$DESKTOP\` + projectName + `.lnk" "$INSTDIR\` + projectName + `.exe"`,
+		`  WriteRegStr HKLM "Software\Microsoft\Windows\CurrentVersion\Uninstall\` + packageName + `" "DisplayName" "` + projectName + `"`,
+		`  WriteRegStr HKLM "Software\Microsoft\Windows\CurrentVersion\Uninstall\` + packageName + `" "UninstallString" "$INSTDIR\uninstall.exe"`,
+		`  WriteRegStr HKLM "Software\Microsoft\Windows\CurrentVersion\Uninstall\` + packageName + `" "DisplayVersion" "` + version + `"`,
+		`  WriteRegDWORD HKLM "Software\Microsoft\Windows\CurrentVersion\Uninstall\` + packageName + `" "NoModify" 1`,
+		`  WriteRegDWORD HKLM "Software\Microsoft\Windows\CurrentVersion\Uninstall\` + packageName + `" "NoRepair" 1`,
+		`SectionEnd`,
+		"",
+		`Section "Uninstall"`,
+		`  Delete "$INSTDIR\uninstall.exe"`,
+		`  RMDir /r "$INSTDIR"`,
+		`  Delete "$SMPROGRAMS\` + projectName + `.lnk"`,
+		`  Delete "$DESKTOP\` + projectName + `.lnk"`,
+		`  DeleteRegKey HKLM "Software\Microsoft\Windows\CurrentVersion\Uninstall\` + packageName + `"`,
+		`SectionEnd`,
+	}
+}
+
+func (p *nsisPackager) Build(projectName string) error {
+	makensisBin, err := exec.LookPath("makensis")
+	if err != nil {
+		return fmt.Errorf("failed to lookup `makensis` executable, please install NSIS: https://nsis.sourceforge.io/")
+	}
+	tmpPath, err := getTemporaryBuildDirectory(projectName, p.Name())
+	if err != nil {
+		return err
+	}
+	fmt.Printf("hover: Packaging nsis installer in %s\n", tmpPath)
+
+	if err := copy.Copy(OutputDirectoryPath("windows"), filepath.Join(tmpPath, "build")); err != nil {
+		return fmt.Errorf("could not copy build folder: %w", err)
+	}
+
+	project := CurrentProject()
+	packagingFormatDirectoryPath, err := packagingFormatPath(project, p.Name())
+	if err != nil {
+		return err
+	}
+	if err := copy.Copy(packagingFormatDirectoryPath, tmpPath); err != nil {
+		return fmt.Errorf("could not copy packaging configuration folder: %w", err)
+	}
+
+	packageName := removeDashesAndUnderscores(projectName)
+	nsiFilePath := filepath.Join(tmpPath, packageName+".nsi")
+
+	cmdBuildNsis := exec.Command(makensisBin, nsiFilePath)
+	cmdBuildNsis.Dir = tmpPath
+	cmdBuildNsis.Stdout = os.Stdout
+	cmdBuildNsis.Stderr = os.Stderr
+	cmdBuildNsis.Stdin = os.Stdin
+	if err := cmdBuildNsis.Run(); err != nil {
+		return fmt.Errorf("failed to package nsis installer: %w", err)
+	}
+
+	outputFileName := packageName + "_installer.exe"
+	outputFilePath := filepath.Join(OutputDirectoryPath(p.Name()), outputFileName)
+	if err := os.Rename(filepath.Join(tmpPath, outputFileName), outputFilePath); err != nil {
+		return fmt.Errorf("could not move installer file: %w", err)
+	}
+	if err := os.RemoveAll(tmpPath); err != nil {
+		return fmt.Errorf("could not remove packaging configuration folder: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,91 @@
+// Package packaging implements hover's pluggable packaging formats. Each
+// format (snap, deb, rpm, ...) registers a Packager from its own file's
+// init() function, and cmd drives `init-packaging`/`build` by iterating the
+// registry instead of hard-coding a command per format.
+package packaging
+
+import "sort"
+
+// Project is the subset of hover's project state every Packager needs to
+// generate configuration files and build artifacts. cmd cannot be imported
+// here without creating an import cycle, so it wires this up once at
+// startup instead.
+type Project struct {
+	Name        string
+	Version     string
+	Description string
+	Author      string
+	BuildPath   string
+	Desktop     DesktopEntryOptions
+}
+
+// CurrentProject returns metadata for the project currently being packaged.
+// Set by cmd at startup.
+var CurrentProject func() Project
+
+// OutputDirectoryPath resolves the directory a finished package of the
+// given format should be written to. Set by cmd at startup.
+var OutputDirectoryPath func(packagingFormat string) string
+
+// Packager is a single packaging format, e.g. linux-snap or linux-deb.
+// Implementations register themselves with Register from an init() func.
+type Packager interface {
+	// Name is the packaging format identifier, e.g. "linux-snap". It is
+	// used as the `init-packaging`/`build` subcommand name and as the
+	// directory name under go/packaging.
+	Name() string
+	// RequiredOS is the runtime.GOOS this format can only be built on, or
+	// "" if it can be built from any host OS.
+	RequiredOS() string
+	// Dependencies lists the runtime dependency package names, as named by
+	// this format's target distribution (e.g. Debian/Ubuntu for linux-deb,
+	// Fedora for linux-rpm, Arch for linux-pkg), or nil if it has none.
+	Dependencies() []string
+	// Init scaffolds go/packaging/<Name()> with this format's
+	// configuration files.
+	Init(projectName string) error
+	// Build packages the current release build of projectName into this
+	// format, writing the result via OutputDirectoryPath.
+	Build(projectName string) error
+}
+
+// ChannelPusher is implemented by packagers that can push a built package to
+// a release channel, e.g. linux-snap via `snapcraft push --release`. cmd
+// only exposes the `--channel` flag for packagers implementing it.
+type ChannelPusher interface {
+	SetReleaseChannel(channel string)
+}
+
+var registry = map[string]Packager{}
+
+// Register adds a Packager to the registry. It is called from the init()
+// function of the file implementing the format and panics on a duplicate
+// name, since that can only be a programming error.
+func Register(packager Packager) {
+	if _, exists := registry[packager.Name()]; exists {
+		panic("packaging: a packager is already registered for " + packager.Name())
+	}
+	registry[packager.Name()] = packager
+}
+
+// All returns every registered Packager, sorted by name so generated
+// commands and output are in a stable order.
+func All() []Packager {
+	names := make([]string, 0, len(registry))
+	for name := range registry {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	packagers := make([]Packager, 0, len(registry))
+	for _, name := range names {
+		packagers = append(packagers, registry[name])
+	}
+	return packagers
+}
+
+// Get returns the registered Packager for name, or nil if none is
+// registered.
+func Get(name string) Packager {
+	return registry[name]
+}
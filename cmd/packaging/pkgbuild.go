@@ -0,0 +1,174 @@
+package packaging
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/otiai10/copy"
+)
+
+func init() {
+	Register(&pkgbuildPackager{})
+}
+
+// pkgbuildPackagingDependencies are the Arch package names for the same
+// runtime libraries linuxPackagingDependencies names for Debian/Ubuntu.
+var pkgbuildPackagingDependencies = []string{"libx11", "libxrandr", "libxcursor", "libxinerama"}
+
+// pkgbuildPackager builds an Arch Linux pkg.tar.zst package via makepkg.
+type pkgbuildPackager struct{}
+
+func (p *pkgbuildPackager) Name() string           { return "linux-pkg" }
+func (p *pkgbuildPackager) RequiredOS() string     { return "linux" }
+func (p *pkgbuildPackager) Dependencies() []string { return pkgbuildPackagingDependencies }
+
+// quotedPkgbuildDependencies renders dependencies as a PKGBUILD array
+// literal, e.g. ('libx11' 'libxrandr').
+func quotedPkgbuildDependencies(dependencies []string) string {
+	quoted := make([]string, len(dependencies))
+	for i, dependency := range dependencies {
+		quoted[i] = "'" + dependency + "'"
+	}
+	return "(" + strings.Join(quoted, " ") + ")"
+}
+
+func (p *pkgbuildPackager) Init(projectName string) error {
+	if err := assertCorrectOS(p); err != nil {
+		return err
+	}
+	project := CurrentProject()
+	pkgDirectoryPath, err := createPackagingFormatDirectory(project, p.Name())
+	if err != nil {
+		return err
+	}
+
+	binDirectoryPath, err := filepath.Abs(filepath.Join(pkgDirectoryPath, "usr", "bin"))
+	if err != nil {
+		return fmt.Errorf("failed to resolve absolute path for bin directory: %w", err)
+	}
+	if err := os.MkdirAll(binDirectoryPath, 0775); err != nil {
+		return fmt.Errorf("failed to create bin directory %s: %w", binDirectoryPath, err)
+	}
+	applicationsDirectoryPath, err := filepath.Abs(filepath.Join(pkgDirectoryPath, "usr", "share", "applications"))
+	if err != nil {
+		return fmt.Errorf("failed to resolve absolute path for applications directory: %w", err)
+	}
+	if err := os.MkdirAll(applicationsDirectoryPath, 0775); err != nil {
+		return fmt.Errorf("failed to create applications directory %s: %w", applicationsDirectoryPath, err)
+	}
+
+	packageName := removeDashesAndUnderscores(projectName)
+	pkgbuildFilePath, err := filepath.Abs(filepath.Join(pkgDirectoryPath, "PKGBUILD"))
+	if err != nil {
+		return fmt.Errorf("failed to resolve absolute path for PKGBUILD file: %w", err)
+	}
+	if err := writeLinesToFile(pkgbuildFilePath, []string{
+		"pkgname=" + packageName,
+		"pkgver=" + project.Version,
+		"pkgrel=1",
+		"pkgdesc=\"" + project.Description + "\"",
+		"arch=('" + pacmanArch(runtime.GOARCH) + "')",
+		"license=('custom')",
+		"depends=" + quotedPkgbuildDependencies(p.Dependencies()),
+		"",
+		"package() {",
+		"  mkdir -p \"$pkgdir/usr/lib/" + projectName + "\"",
+		"  cp -r \"$startdir/usr/lib/" + projectName + "/\"* \"$pkgdir/usr/lib/" + projectName + "/\"",
+		"  mkdir -p \"$pkgdir/usr/bin\"",
+		"  cp \"$startdir/usr/bin/" + packageName + "\" \"$pkgdir/usr/bin/" + packageName + "\"",
+		"  mkdir -p \"$pkgdir/usr/share/applications\"",
+		"  cp \"$startdir/usr/share/applications/" + projectName + ".desktop\" \"$pkgdir/usr/share/applications/" + projectName + ".desktop\"",
+		"}",
+	}); err != nil {
+		return err
+	}
+
+	binFilePath, err := filepath.Abs(filepath.Join(binDirectoryPath, packageName))
+	if err != nil {
+		return fmt.Errorf("failed to resolve absolute path for bin file: %w", err)
+	}
+	if err := writeLinesToFile(binFilePath, launcherScriptContent(projectName)); err != nil {
+		return err
+	}
+	if err := os.Chmod(binFilePath, 0777); err != nil {
+		return fmt.Errorf("failed to change file permissions for bin file: %w", err)
+	}
+
+	desktopFilePath, err := filepath.Abs(filepath.Join(applicationsDirectoryPath, projectName+".desktop"))
+	if err != nil {
+		return fmt.Errorf("failed to resolve absolute path for desktop file: %w", err)
+	}
+	if err := writeDesktopEntry(desktopFilePath, DesktopEntry{
+		Type:           "Application",
+		Name:           projectName,
+		GenericName:    project.Desktop.GenericName,
+		Comment:        project.Desktop.Comment,
+		Exec:           "/usr/bin/" + packageName,
+		Icon:           "/usr/lib/" + projectName + "/assets/icon.png",
+		Terminal:       false,
+		Categories:     project.Desktop.Categories,
+		MimeTypes:      project.Desktop.MimeTypes,
+		Keywords:       project.Desktop.Keywords,
+		StartupWMClass: project.Desktop.StartupWMClass,
+	}); err != nil {
+		return err
+	}
+
+	printInitFinished(p.Name())
+	return nil
+}
+
+func (p *pkgbuildPackager) Build(projectName string) error {
+	if err := assertCorrectOS(p); err != nil {
+		return err
+	}
+	makepkgBin, err := exec.LookPath("makepkg")
+	if err != nil {
+		return fmt.Errorf("failed to lookup `makepkg` executable, please install the `pacman` package")
+	}
+	tmpPath, err := getTemporaryBuildDirectory(projectName, p.Name())
+	if err != nil {
+		return err
+	}
+	fmt.Printf("hover: Packaging pkg.tar.zst in %s\n", tmpPath)
+
+	libDirectoryPath, err := filepath.Abs(filepath.Join(tmpPath, "usr", "lib"))
+	if err != nil {
+		return fmt.Errorf("failed to resolve absolute path for lib directory: %w", err)
+	}
+	if err := copy.Copy(OutputDirectoryPath("linux"), filepath.Join(libDirectoryPath, projectName)); err != nil {
+		return fmt.Errorf("could not copy build folder: %w", err)
+	}
+
+	project := CurrentProject()
+	packagingFormatDirectoryPath, err := packagingFormatPath(project, p.Name())
+	if err != nil {
+		return err
+	}
+	if err := copy.Copy(packagingFormatDirectoryPath, tmpPath); err != nil {
+		return fmt.Errorf("could not copy packaging configuration folder: %w", err)
+	}
+
+	cmdBuildPkg := exec.Command(makepkgBin, "-f")
+	cmdBuildPkg.Dir = tmpPath
+	cmdBuildPkg.Stdout = os.Stdout
+	cmdBuildPkg.Stderr = os.Stderr
+	cmdBuildPkg.Stdin = os.Stdin
+	if err := cmdBuildPkg.Run(); err != nil {
+		return fmt.Errorf("failed to package pkg.tar.zst: %w", err)
+	}
+
+	outputFileName := fmt.Sprintf("%s-%s-1-%s.pkg.tar.zst", removeDashesAndUnderscores(projectName), project.Version, pacmanArch(runtime.GOARCH))
+	outputFilePath := filepath.Join(OutputDirectoryPath(p.Name()), outputFileName)
+	if err := os.Rename(filepath.Join(tmpPath, outputFileName), outputFilePath); err != nil {
+		return fmt.Errorf("could not move pkg.tar.zst file: %w", err)
+	}
+	if err := os.RemoveAll(tmpPath); err != nil {
+		return fmt.Errorf("could not remove packaging configuration folder: %w", err)
+	}
+	return nil
+}
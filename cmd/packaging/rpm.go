@@ -0,0 +1,189 @@
+package packaging
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/otiai10/copy"
+)
+
+func init() {
+	Register(&rpmPackager{})
+}
+
+// rpmPackagingDependencies are the Fedora/openSUSE package names for the
+// same runtime libraries linuxPackagingDependencies names for Debian/Ubuntu.
+var rpmPackagingDependencies = []string{"libX11", "libXrandr", "libXcursor", "libXinerama"}
+
+type rpmPackager struct{}
+
+func (p *rpmPackager) Name() string           { return "linux-rpm" }
+func (p *rpmPackager) RequiredOS() string     { return "linux" }
+func (p *rpmPackager) Dependencies() []string { return rpmPackagingDependencies }
+
+func (p *rpmPackager) Init(projectName string) error {
+	if err := assertCorrectOS(p); err != nil {
+		return err
+	}
+	project := CurrentProject()
+	rpmDirectoryPath, err := createPackagingFormatDirectory(project, p.Name())
+	if err != nil {
+		return err
+	}
+
+	binDirectoryPath, err := filepath.Abs(filepath.Join(rpmDirectoryPath, "usr", "bin"))
+	if err != nil {
+		return fmt.Errorf("failed to resolve absolute path for bin directory: %w", err)
+	}
+	if err := os.MkdirAll(binDirectoryPath, 0775); err != nil {
+		return fmt.Errorf("failed to create bin directory %s: %w", binDirectoryPath, err)
+	}
+	applicationsDirectoryPath, err := filepath.Abs(filepath.Join(rpmDirectoryPath, "usr", "share", "applications"))
+	if err != nil {
+		return fmt.Errorf("failed to resolve absolute path for applications directory: %w", err)
+	}
+	if err := os.MkdirAll(applicationsDirectoryPath, 0775); err != nil {
+		return fmt.Errorf("failed to create applications directory %s: %w", applicationsDirectoryPath, err)
+	}
+
+	packageName := removeDashesAndUnderscores(projectName)
+	specFilePath, err := filepath.Abs(filepath.Join(rpmDirectoryPath, packageName+".spec"))
+	if err != nil {
+		return fmt.Errorf("failed to resolve absolute path for spec file: %w", err)
+	}
+	if err := writeLinesToFile(specFilePath, []string{
+		"Name: " + packageName,
+		"Version: " + project.Version,
+		"Release: 1%{?dist}",
+		"Summary: " + project.Description,
+		"License: Proprietary",
+		"Requires: " + strings.Join(p.Dependencies(), ", "),
+		"",
+		"%description",
+		project.Description,
+		"",
+		"%install",
+		"mkdir -p %{buildroot}/usr/lib/" + projectName,
+		"cp -r %{_sourcedir}/usr/lib/" + projectName + "/* %{buildroot}/usr/lib/" + projectName + "/",
+		"mkdir -p %{buildroot}/usr/bin",
+		"cp %{_sourcedir}/usr/bin/" + packageName + " %{buildroot}/usr/bin/" + packageName,
+		"mkdir -p %{buildroot}/usr/share/applications",
+		"cp %{_sourcedir}/usr/share/applications/" + projectName + ".desktop %{buildroot}/usr/share/applications/" + projectName + ".desktop",
+		"",
+		"%files",
+		"/usr/lib/" + projectName,
+		"/usr/bin/" + packageName,
+		"/usr/share/applications/" + projectName + ".desktop",
+	}); err != nil {
+		return err
+	}
+
+	binFilePath, err := filepath.Abs(filepath.Join(binDirectoryPath, packageName))
+	if err != nil {
+		return fmt.Errorf("failed to resolve absolute path for bin file: %w", err)
+	}
+	if err := writeLinesToFile(binFilePath, launcherScriptContent(projectName)); err != nil {
+		return err
+	}
+	if err := os.Chmod(binFilePath, 0777); err != nil {
+		return fmt.Errorf("failed to change file permissions for bin file: %w", err)
+	}
+
+	desktopFilePath, err := filepath.Abs(filepath.Join(applicationsDirectoryPath, projectName+".desktop"))
+	if err != nil {
+		return fmt.Errorf("failed to resolve absolute path for desktop file: %w", err)
+	}
+	if err := writeDesktopEntry(desktopFilePath, DesktopEntry{
+		Type:           "Application",
+		Name:           projectName,
+		GenericName:    project.Desktop.GenericName,
+		Comment:        project.Desktop.Comment,
+		Exec:           "/usr/bin/" + packageName,
+		Icon:           "/usr/lib/" + projectName + "/assets/icon.png",
+		Terminal:       false,
+		Categories:     project.Desktop.Categories,
+		MimeTypes:      project.Desktop.MimeTypes,
+		Keywords:       project.Desktop.Keywords,
+		StartupWMClass: project.Desktop.StartupWMClass,
+	}); err != nil {
+		return err
+	}
+
+	printInitFinished(p.Name())
+	return nil
+}
+
+func (p *rpmPackager) Build(projectName string) error {
+	if err := assertCorrectOS(p); err != nil {
+		return err
+	}
+	rpmbuildBin, err := exec.LookPath("rpmbuild")
+	if err != nil {
+		return fmt.Errorf("failed to lookup `rpmbuild` executable, please install rpmbuild")
+	}
+	tmpPath, err := getTemporaryBuildDirectory(projectName, p.Name())
+	if err != nil {
+		return err
+	}
+	fmt.Printf("hover: Packaging rpm in %s\n", tmpPath)
+
+	libDirectoryPath, err := filepath.Abs(filepath.Join(tmpPath, "usr", "lib"))
+	if err != nil {
+		return fmt.Errorf("failed to resolve absolute path for lib directory: %w", err)
+	}
+	if err := copy.Copy(OutputDirectoryPath("linux"), filepath.Join(libDirectoryPath, projectName)); err != nil {
+		return fmt.Errorf("could not copy build folder: %w", err)
+	}
+
+	project := CurrentProject()
+	packagingFormatDirectoryPath, err := packagingFormatPath(project, p.Name())
+	if err != nil {
+		return err
+	}
+	if err := copy.Copy(packagingFormatDirectoryPath, tmpPath); err != nil {
+		return fmt.Errorf("could not copy packaging configuration folder: %w", err)
+	}
+
+	packageName := removeDashesAndUnderscores(projectName)
+	outputFileName := packageName + "-" + project.Version + "-1." + runtime.GOARCH + ".rpm"
+	outputFilePath := filepath.Join(OutputDirectoryPath(p.Name()), outputFileName)
+
+	cmdBuildRpm := exec.Command(rpmbuildBin, "-bb",
+		"--define", "_topdir "+tmpPath,
+		"--define", "_sourcedir "+tmpPath,
+		"--define", "_rpmdir "+tmpPath,
+		"--buildroot", filepath.Join(tmpPath, "buildroot"),
+		filepath.Join(tmpPath, packageName+".spec"))
+	cmdBuildRpm.Dir = tmpPath
+	cmdBuildRpm.Stdout = os.Stdout
+	cmdBuildRpm.Stderr = os.Stderr
+	cmdBuildRpm.Stdin = os.Stdin
+	if err := cmdBuildRpm.Run(); err != nil {
+		return fmt.Errorf("failed to package rpm: %w", err)
+	}
+
+	var builtRpmPath string
+	err = filepath.Walk(tmpPath, func(path string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() {
+			return err
+		}
+		if strings.HasSuffix(path, ".rpm") {
+			builtRpmPath = path
+		}
+		return nil
+	})
+	if err != nil || builtRpmPath == "" {
+		return fmt.Errorf("could not find built rpm file: %w", err)
+	}
+	if err := os.Rename(builtRpmPath, outputFilePath); err != nil {
+		return fmt.Errorf("could not move rpm file: %w", err)
+	}
+	if err := os.RemoveAll(tmpPath); err != nil {
+		return fmt.Errorf("could not remove packaging configuration folder: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,289 @@
+package packaging
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/otiai10/copy"
+	"gopkg.in/yaml.v2"
+)
+
+func init() {
+	Register(&snapPackager{})
+}
+
+// snapSummaryMaxLength is enforced by the snap store; snapcraft rejects
+// anything longer at push time.
+const snapSummaryMaxLength = 78
+
+var (
+	ErrNoSummary        = errors.New("no summary set, check your pubspec.yaml")
+	ErrNoDescription    = errors.New("no description set, check your pubspec.yaml")
+	ErrSummaryTooLong   = errors.New("summary is longer than 78 characters, check your pubspec.yaml")
+	ErrNoGrade          = errors.New("no grade set")
+	snapReleaseChannels = []string{"edge", "beta", "candidate", "stable"}
+)
+
+// SnapMetadata mirrors the subset of the snapcraft.yaml schema hover is able
+// to generate. See https://snapcraft.io/docs/snapcraft-yaml-reference.
+type SnapMetadata struct {
+	Name        string              `yaml:"name"`
+	Base        string              `yaml:"base"`
+	Version     string              `yaml:"version"`
+	Summary     string              `yaml:"summary"`
+	Description string              `yaml:"description"`
+	Confinement string              `yaml:"confinement"`
+	Grade       string              `yaml:"grade"`
+	Apps        map[string]SnapApp  `yaml:"apps"`
+	Plugs       map[string]SnapPlug `yaml:"plugs,omitempty"`
+	Slots       map[string]SnapPlug `yaml:"slots,omitempty"`
+	Parts       map[string]SnapPart `yaml:"parts"`
+}
+
+// SnapApp is a single entry under the top-level `apps` key.
+type SnapApp struct {
+	Command     string            `yaml:"command"`
+	Desktop     string            `yaml:"desktop,omitempty"`
+	Plugs       []string          `yaml:"plugs,omitempty"`
+	Completer   string            `yaml:"completer,omitempty"`
+	Environment map[string]string `yaml:"environment,omitempty"`
+}
+
+// SnapPlug is a custom interface plug or slot declaration.
+type SnapPlug struct {
+	Interface string `yaml:"interface,omitempty"`
+}
+
+// SnapPart is a single entry under the top-level `parts` key.
+type SnapPart struct {
+	Plugin        string   `yaml:"plugin"`
+	Source        string   `yaml:"source"`
+	StagePackages []string `yaml:"stage-packages,omitempty"`
+	BuildPackages []string `yaml:"build-packages,omitempty"`
+	OverridePull  string   `yaml:"override-pull,omitempty"`
+	OverrideBuild string   `yaml:"override-build,omitempty"`
+	OverrideStage string   `yaml:"override-stage,omitempty"`
+	OverridePrime string   `yaml:"override-prime,omitempty"`
+}
+
+// defaultSnapPlugs are the interface plugs added to every app so a Flutter
+// GUI can run under `strict` confinement instead of `devmode`.
+var defaultSnapPlugs = []string{"desktop", "desktop-legacy", "home", "wayland", "x11", "opengl", "network", "pulseaudio"}
+
+func validateSnapMetadata(metadata SnapMetadata) error {
+	if metadata.Summary == "" {
+		return ErrNoSummary
+	}
+	if len(metadata.Summary) > snapSummaryMaxLength {
+		return ErrSummaryTooLong
+	}
+	if metadata.Description == "" {
+		return ErrNoDescription
+	}
+	if metadata.Grade == "" {
+		return ErrNoGrade
+	}
+	return nil
+}
+
+// validateSnapcraftYamlOnDisk re-reads and re-validates go/packaging/linux-snap's
+// snapcraft.yaml before invoking snapcraft. Init only validates metadata
+// freshly derived from pubspec.yaml, which can't catch a user hand-editing
+// the generated yaml afterwards.
+func validateSnapcraftYamlOnDisk(project Project) error {
+	snapDirectoryPath, err := packagingFormatPath(project, "linux-snap")
+	if err != nil {
+		return err
+	}
+	snapcraftFilePath := filepath.Join(snapDirectoryPath, "snap", "snapcraft.yaml")
+
+	content, err := os.ReadFile(snapcraftFilePath)
+	if err != nil {
+		return fmt.Errorf("could not read %s: %w", snapcraftFilePath, err)
+	}
+	var metadata SnapMetadata
+	if err := yaml.Unmarshal(content, &metadata); err != nil {
+		return fmt.Errorf("could not parse %s: %w", snapcraftFilePath, err)
+	}
+	if err := validateSnapMetadata(metadata); err != nil {
+		return fmt.Errorf("invalid snap metadata in %s: %w", snapcraftFilePath, err)
+	}
+	return nil
+}
+
+type snapPackager struct {
+	// ReleaseChannel is set by cmd from the `build linux-snap --channel`
+	// flag before Build is called. Empty means skip the `snapcraft push`.
+	ReleaseChannel string
+}
+
+func (p *snapPackager) Name() string           { return "linux-snap" }
+func (p *snapPackager) RequiredOS() string     { return "linux" }
+func (p *snapPackager) Dependencies() []string { return linuxPackagingDependencies }
+
+// SetReleaseChannel implements packaging.ChannelPusher.
+func (p *snapPackager) SetReleaseChannel(channel string) { p.ReleaseChannel = channel }
+
+func (p *snapPackager) Init(projectName string) error {
+	if err := assertCorrectOS(p); err != nil {
+		return err
+	}
+	project := CurrentProject()
+	snapDirectoryPath, err := createPackagingFormatDirectory(project, p.Name())
+	if err != nil {
+		return err
+	}
+
+	snapLocalDirectoryPath, err := filepath.Abs(filepath.Join(snapDirectoryPath, "snap", "local"))
+	if err != nil {
+		return fmt.Errorf("failed to resolve absolute path for snap local directory: %w", err)
+	}
+	if err := os.MkdirAll(snapLocalDirectoryPath, 0775); err != nil {
+		return fmt.Errorf("failed to create snap local directory %s: %w", snapLocalDirectoryPath, err)
+	}
+
+	metadata := SnapMetadata{
+		Name:        removeDashesAndUnderscores(projectName),
+		Base:        "core18",
+		Version:     project.Version,
+		Summary:     project.Description,
+		Description: project.Description,
+		Confinement: "strict",
+		Grade:       "stable",
+		Apps: map[string]SnapApp{
+			removeDashesAndUnderscores(projectName): {
+				Command: projectName,
+				Desktop: "local/" + projectName + ".desktop",
+				Plugs:   defaultSnapPlugs,
+			},
+		},
+		Parts: map[string]SnapPart{
+			"desktop": {Plugin: "dump", Source: "snap"},
+			"assets":  {Plugin: "dump", Source: "assets"},
+			"app":     {Plugin: "dump", Source: "build", StagePackages: p.Dependencies()},
+		},
+	}
+	if err := validateSnapMetadata(metadata); err != nil {
+		return fmt.Errorf("invalid snap metadata: %w", err)
+	}
+
+	snapcraftFileContent, err := yaml.Marshal(metadata)
+	if err != nil {
+		return fmt.Errorf("could not marshal snapcraft.yaml: %w", err)
+	}
+	snapcraftFilePath, err := filepath.Abs(filepath.Join(snapDirectoryPath, "snap", "snapcraft.yaml"))
+	if err != nil {
+		return fmt.Errorf("failed to resolve absolute path for snapcraft.yaml file: %w", err)
+	}
+	if err := os.WriteFile(snapcraftFilePath, snapcraftFileContent, 0664); err != nil {
+		return fmt.Errorf("could not write snapcraft.yaml: %w", err)
+	}
+
+	desktopFilePath, err := filepath.Abs(filepath.Join(snapLocalDirectoryPath, projectName+".desktop"))
+	if err != nil {
+		return fmt.Errorf("failed to resolve absolute path for desktop file: %w", err)
+	}
+	if err := writeDesktopEntry(desktopFilePath, DesktopEntry{
+		Type:           "Application",
+		Name:           projectName,
+		GenericName:    project.Desktop.GenericName,
+		Comment:        project.Desktop.Comment,
+		Exec:           "/" + projectName,
+		Icon:           "/icon.png",
+		Terminal:       false,
+		Categories:     project.Desktop.Categories,
+		MimeTypes:      project.Desktop.MimeTypes,
+		Keywords:       project.Desktop.Keywords,
+		StartupWMClass: project.Desktop.StartupWMClass,
+	}); err != nil {
+		return err
+	}
+
+	printInitFinished(p.Name())
+	return nil
+}
+
+func (p *snapPackager) Build(projectName string) error {
+	if err := assertCorrectOS(p); err != nil {
+		return err
+	}
+	if p.ReleaseChannel != "" && !contains(snapReleaseChannels, p.ReleaseChannel) {
+		return fmt.Errorf("invalid --channel %s, must be one of: %s", p.ReleaseChannel, strings.Join(snapReleaseChannels, ", "))
+	}
+
+	project := CurrentProject()
+	if err := validateSnapcraftYamlOnDisk(project); err != nil {
+		return err
+	}
+
+	snapcraftBin, err := exec.LookPath("snapcraft")
+	if err != nil {
+		return fmt.Errorf("failed to lookup `snapcraft` executable, please install snapcraft: https://tutorials.ubuntu.com/tutorial/create-your-first-snap#1")
+	}
+
+	tmpPath, err := getTemporaryBuildDirectory(projectName, p.Name())
+	if err != nil {
+		return err
+	}
+	fmt.Printf("hover: Packaging snap in %s\n", tmpPath)
+
+	if err := copy.Copy(filepath.Join(project.BuildPath, "assets"), filepath.Join(tmpPath, "assets")); err != nil {
+		return fmt.Errorf("could not copy assets folder: %w", err)
+	}
+	if err := copy.Copy(OutputDirectoryPath("linux"), filepath.Join(tmpPath, "build")); err != nil {
+		return fmt.Errorf("could not copy build folder: %w", err)
+	}
+	packagingFormatDirectoryPath, err := packagingFormatPath(project, p.Name())
+	if err != nil {
+		return err
+	}
+	if err := copy.Copy(packagingFormatDirectoryPath, tmpPath); err != nil {
+		return fmt.Errorf("could not copy packaging configuration folder: %w", err)
+	}
+
+	cmdBuildSnap := exec.Command(snapcraftBin)
+	cmdBuildSnap.Dir = tmpPath
+	cmdBuildSnap.Stdout = os.Stdout
+	cmdBuildSnap.Stderr = os.Stderr
+	cmdBuildSnap.Stdin = os.Stdin
+	if err := cmdBuildSnap.Run(); err != nil {
+		return fmt.Errorf("failed to package snap: %w", err)
+	}
+
+	outputFilePath := filepath.Join(OutputDirectoryPath(p.Name()), removeDashesAndUnderscores(projectName)+"_"+runtime.GOARCH+".snap")
+	snapFilePath := filepath.Join(tmpPath, removeDashesAndUnderscores(projectName)+"_"+project.Version+"_"+runtime.GOARCH+".snap")
+
+	if p.ReleaseChannel != "" {
+		fmt.Printf("hover: Pushing snap to the %s channel\n", p.ReleaseChannel)
+		cmdPushSnap := exec.Command(snapcraftBin, "push", snapFilePath, "--release="+p.ReleaseChannel)
+		cmdPushSnap.Dir = tmpPath
+		cmdPushSnap.Stdout = os.Stdout
+		cmdPushSnap.Stderr = os.Stderr
+		cmdPushSnap.Stdin = os.Stdin
+		if err := cmdPushSnap.Run(); err != nil {
+			return fmt.Errorf("failed to push snap to %s: %w", p.ReleaseChannel, err)
+		}
+	}
+
+	if err := os.Rename(snapFilePath, outputFilePath); err != nil {
+		return fmt.Errorf("could not move snap file: %w", err)
+	}
+	if err := os.RemoveAll(tmpPath); err != nil {
+		return fmt.Errorf("could not remove packaging configuration folder: %w", err)
+	}
+	return nil
+}
+
+func contains(list []string, item string) bool {
+	for _, v := range list {
+		if v == item {
+			return true
+		}
+	}
+	return false
+}
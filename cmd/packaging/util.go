@@ -0,0 +1,110 @@
+package packaging
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+)
+
+var linuxPackagingDependencies = []string{"libx11-6", "libxrandr2", "libxcursor1", "libxinerama1"}
+
+func packagingFormatPath(project Project, packagingFormat string) (string, error) {
+	directoryPath, err := filepath.Abs(filepath.Join(project.BuildPath, "packaging", packagingFormat))
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve absolute path for %s directory: %w", packagingFormat, err)
+	}
+	return directoryPath, nil
+}
+
+func createPackagingFormatDirectory(project Project, packagingFormat string) (string, error) {
+	directoryPath, err := packagingFormatPath(project, packagingFormat)
+	if err != nil {
+		return "", err
+	}
+	if _, err := os.Stat(directoryPath); !os.IsNotExist(err) {
+		return "", fmt.Errorf("a file or directory named `%s` already exists, cannot continue packaging init for %s", packagingFormat, packagingFormat)
+	}
+	if err := os.MkdirAll(directoryPath, 0775); err != nil {
+		return "", fmt.Errorf("failed to create %s directory %s: %w", packagingFormat, directoryPath, err)
+	}
+	return directoryPath, nil
+}
+
+// AssertInitialized returns an error if packagingFormat has not yet been
+// scaffolded with `hover init-packaging`.
+func AssertInitialized(project Project, packagingFormat string) error {
+	directoryPath, err := packagingFormatPath(project, packagingFormat)
+	if err != nil {
+		return err
+	}
+	if _, err := os.Stat(directoryPath); os.IsNotExist(err) {
+		return fmt.Errorf("%s is not initialized for packaging, run `hover init-packaging %s` first", packagingFormat, packagingFormat)
+	}
+	return nil
+}
+
+func assertCorrectOS(packager Packager) error {
+	if packager.RequiredOS() != "" && runtime.GOOS != packager.RequiredOS() {
+		return fmt.Errorf("%s only works on %s", packager.Name(), packager.RequiredOS())
+	}
+	return nil
+}
+
+func removeDashesAndUnderscores(projectName string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(projectName, "-", ""), "_", "")
+}
+
+func getTemporaryBuildDirectory(projectName string, packagingFormat string) (string, error) {
+	tmpPath, err := ioutil.TempDir("", "hover-build-"+projectName+"-"+packagingFormat)
+	if err != nil {
+		return "", fmt.Errorf("couldn't get temporary build directory: %w", err)
+	}
+	return tmpPath, nil
+}
+
+// writeLinesToFile creates filePath and writes lines to it, one per line.
+// It exists to cut down on the create/write/close boilerplate repeated by
+// every packaging format.
+func writeLinesToFile(filePath string, lines []string) error {
+	file, err := os.Create(filePath)
+	if err != nil {
+		return fmt.Errorf("failed to create file %s: %w", filePath, err)
+	}
+	defer file.Close()
+	for _, line := range lines {
+		if _, err := file.WriteString(line + "\n"); err != nil {
+			return fmt.Errorf("could not write to %s: %w", filePath, err)
+		}
+	}
+	return nil
+}
+
+// launcherScriptContent is the shared `/usr/bin/<project>` wrapper installed
+// by every Linux package format that puts the build output under
+// `/usr/lib/<project>`.
+func launcherScriptContent(projectName string) []string {
+	return []string{
+		"#!/bin/sh",
+		"/usr/lib/" + projectName + "/" + projectName,
+	}
+}
+
+// pacmanArch maps a Go GOARCH to the arch name pacman/makepkg expects.
+func pacmanArch(goarch string) string {
+	switch goarch {
+	case "amd64":
+		return "x86_64"
+	case "arm64":
+		return "aarch64"
+	default:
+		return goarch
+	}
+}
+
+func printInitFinished(packagingFormat string) {
+	fmt.Printf("hover: go/packaging/%s has been created. You can modify the configuration files and add it to git.\n", packagingFormat)
+	fmt.Printf("hover: You now can package the %s using `hover build %s`\n", strings.Split(packagingFormat, "-")[0], packagingFormat)
+}